@@ -0,0 +1,102 @@
+package polkit
+
+import (
+	"context"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// authenticationAgentInterface is the D-Bus interface an authentication
+// agent must implement and that it registers itself under with
+// RegisterAuthenticationAgent.
+const authenticationAgentInterface = "org.freedesktop.PolicyKit1.AuthenticationAgent"
+
+// PKIdentity identifies a user or group that may be asked to authenticate,
+// in the same Kind/Details shape PolicyKit uses for PKSubject.
+type PKIdentity struct {
+	Kind    string                  `dbus:"identity_kind"`
+	Details map[string]dbus.Variant `dbus:"identity_details"`
+}
+
+// NewUnixUserIdentity builds a PKIdentity for a local user account.
+func NewUnixUserIdentity(uid uint32) PKIdentity {
+	return PKIdentity{
+		Kind: "unix-user",
+		Details: map[string]dbus.Variant{
+			"uid": dbus.MakeVariant(uid),
+		},
+	}
+}
+
+// NewUnixGroupIdentity builds a PKIdentity for a local group.
+func NewUnixGroupIdentity(gid uint32) PKIdentity {
+	return PKIdentity{
+		Kind: "unix-group",
+		Details: map[string]dbus.Variant{
+			"gid": dbus.MakeVariant(gid),
+		},
+	}
+}
+
+// AuthenticationAgent is implemented by programs that want to act as a
+// PolicyKit authentication agent, e.g. a text-mode or TUI prompt for
+// headless servers and containers. Register one with
+// Authority.RegisterAuthenticationAgent and Authority.ExportAuthenticationAgent.
+type AuthenticationAgent interface {
+	// BeginAuthentication is called by PolicyKit when actionID needs
+	// authentication from one of identities. Implementations should prompt
+	// for credentials and, once the user has authenticated (or declined),
+	// report the outcome to PolicyKit via
+	// Authority.AuthenticationAgentResponse2 using cookie. ctx is cancelled
+	// when the underlying bus connection is closed; it does not carry a
+	// deadline or cancellation of its own.
+	BeginAuthentication(ctx context.Context, actionID, message, iconName string, details map[string]string, cookie string, identities []PKIdentity) error
+}
+
+// RegisterAuthenticationAgent registers the process as the authentication
+// agent for subject (typically the caller's own session, built with
+// NewUnixSessionSubject). objectPath must match the path the agent was
+// exported on with ExportAuthenticationAgent.
+func (a *Authority) RegisterAuthenticationAgent(subject PKSubject, locale, objectPath string) error {
+	return a.call("RegisterAuthenticationAgent", nil, subject, locale, objectPath)
+}
+
+// UnregisterAuthenticationAgent undoes a prior RegisterAuthenticationAgent.
+func (a *Authority) UnregisterAuthenticationAgent(subject PKSubject, objectPath string) error {
+	return a.call("UnregisterAuthenticationAgent", nil, subject, objectPath)
+}
+
+// AuthenticationAgentResponse2 reports the outcome of authenticating as
+// identity for the request identified by cookie, as handed to
+// AuthenticationAgent.BeginAuthentication.
+func (a *Authority) AuthenticationAgentResponse2(uid uint32, cookie string, identity PKIdentity) error {
+	return a.call("AuthenticationAgentResponse2", nil, uid, cookie, identity)
+}
+
+// ExportAuthenticationAgent exposes agent on the bus at objectPath under the
+// org.freedesktop.PolicyKit1.AuthenticationAgent interface, so PolicyKit can
+// dispatch BeginAuthentication calls to it once it has been registered with
+// RegisterAuthenticationAgent.
+func (a *Authority) ExportAuthenticationAgent(agent AuthenticationAgent, objectPath string) error {
+	server := &authenticationAgentServer{agent: agent, connContext: a.conn.Context}
+	return a.conn.Export(server, dbus.ObjectPath(objectPath), authenticationAgentInterface)
+}
+
+// authenticationAgentServer adapts an AuthenticationAgent to the method
+// signature godbus expects to export on the bus.
+type authenticationAgentServer struct {
+	agent AuthenticationAgent
+
+	// connContext returns the context tied to the bus connection's
+	// lifetime, so BeginAuthentication implementations that watch ctx are
+	// actually cancelled when the connection goes away rather than being
+	// handed a context that can never be done.
+	connContext func() context.Context
+}
+
+func (s *authenticationAgentServer) BeginAuthentication(actionID, message, iconName string, details map[string]string, cookie string, identities []PKIdentity) *dbus.Error {
+	if err := s.agent.BeginAuthentication(s.connContext(), actionID, message, iconName, details, cookie, identities); err != nil {
+		return dbus.MakeFailedError(err)
+	}
+	return nil
+}
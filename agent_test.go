@@ -0,0 +1,80 @@
+package polkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewUnixUserIdentity(t *testing.T) {
+	id := NewUnixUserIdentity(1000)
+	if id.Kind != "unix-user" {
+		t.Errorf("Kind = %q, want %q", id.Kind, "unix-user")
+	}
+	if uid := id.Details["uid"].Value().(uint32); uid != 1000 {
+		t.Errorf("Details[uid] = %v, want 1000", uid)
+	}
+}
+
+func TestNewUnixGroupIdentity(t *testing.T) {
+	id := NewUnixGroupIdentity(2000)
+	if id.Kind != "unix-group" {
+		t.Errorf("Kind = %q, want %q", id.Kind, "unix-group")
+	}
+	if gid := id.Details["gid"].Value().(uint32); gid != 2000 {
+		t.Errorf("Details[gid] = %v, want 2000", gid)
+	}
+}
+
+// fakeAuthenticationAgent is a test double for AuthenticationAgent that
+// records the context it was called with and returns a canned error.
+type fakeAuthenticationAgent struct {
+	gotCtx context.Context
+	err    error
+}
+
+func (f *fakeAuthenticationAgent) BeginAuthentication(ctx context.Context, actionID, message, iconName string, details map[string]string, cookie string, identities []PKIdentity) error {
+	f.gotCtx = ctx
+	return f.err
+}
+
+func TestAuthenticationAgentServerBeginAuthentication(t *testing.T) {
+	t.Run("wires the connection's context through to the agent", func(t *testing.T) {
+		connCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		agent := &fakeAuthenticationAgent{}
+		server := &authenticationAgentServer{agent: agent, connContext: func() context.Context { return connCtx }}
+
+		if dbusErr := server.BeginAuthentication("org.example.foo", "msg", "icon", nil, "cookie", nil); dbusErr != nil {
+			t.Fatalf("BeginAuthentication returned unexpected error: %v", dbusErr)
+		}
+
+		if agent.gotCtx != connCtx {
+			t.Error("agent did not receive the connection's context")
+		}
+
+		// The context should reflect the connection's lifetime: once it's
+		// cancelled, the agent should see that too.
+		cancel()
+		if agent.gotCtx.Err() == nil {
+			t.Error("agent's context was not cancelled when the connection context was")
+		}
+	})
+
+	t.Run("translates an agent error into a D-Bus failed error", func(t *testing.T) {
+		agent := &fakeAuthenticationAgent{err: errors.New("user declined")}
+		server := &authenticationAgentServer{agent: agent, connContext: context.Background}
+
+		dbusErr := server.BeginAuthentication("org.example.foo", "msg", "icon", nil, "cookie", nil)
+		if dbusErr == nil {
+			t.Fatal("BeginAuthentication: expected an error, got nil")
+		}
+		if dbusErr.Name != "org.freedesktop.DBus.Error.Failed" {
+			t.Errorf("dbusErr.Name = %q, want %q", dbusErr.Name, "org.freedesktop.DBus.Error.Failed")
+		}
+		if len(dbusErr.Body) != 1 || dbusErr.Body[0] != "user declined" {
+			t.Errorf("dbusErr.Body = %v, want [%q]", dbusErr.Body, "user declined")
+		}
+	})
+}
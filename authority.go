@@ -2,6 +2,7 @@ package polkit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -98,60 +99,58 @@ func NewAuthority() (*Authority, error) {
 	}, nil
 }
 
+// Deprecated: use EnumerateActionsContext instead. EnumerateActions will be
+// removed in a future release.
 func (a *Authority) EnumerateActions(locale string) ([]PKActionDescription, error) {
-	var result []PKActionDescription
-	if err := a.call("EnumerateActions", &result, locale); err != nil {
-		return nil, err
-	}
-
-	return result, nil
+	return a.EnumerateActionsContext(context.Background(), locale)
 }
 
+// Deprecated: use CheckAuthorizationContext instead. CheckAuthorization will
+// be removed in a future release.
 func (a *Authority) CheckAuthorization(
 	actionID string,
 	details map[string]string,
 	flags uint32,
 	cancellationID string,
 	timeout ...int) (*PKAuthorizationResult, error) {
-	result := PKAuthorizationResult{}
+	return a.CheckAuthorizationFor(a.subject, actionID, details, flags, cancellationID, timeout...)
+}
+
+// Deprecated: use CheckAuthorizationContext instead. CheckAuthorizationFor
+// will be removed in a future release.
+//
+// CheckAuthorizationFor is like CheckAuthorization but checks whether
+// subject is authorized rather than always checking the caller itself. This
+// is the call a privileged daemon makes to authorize another process or
+// session, e.g. one built with NewUnixProcessSubject or
+// NewUnixSessionSubject.
+func (a *Authority) CheckAuthorizationFor(
+	subject PKSubject,
+	actionID string,
+	details map[string]string,
+	flags uint32,
+	cancellationID string,
+	timeout ...int) (*PKAuthorizationResult, error) {
 	actualTimeout := 25
 	if len(timeout) > 0 {
 		actualTimeout = timeout[0]
 	}
-	err := a.callWithTimeout("CheckAuthorization", &result, actualTimeout, a.subject, actionID, details, flags, cancellationID)
-	if err != nil {
-		if err == context.DeadlineExceeded {
-			return nil, fmt.Errorf("authorization check timed out after %d seconds", timeout)
-		}
-		return nil, err
-	}
-
-	return &result, nil
-}
 
-func (a *Authority) callWithTimeout(action string, result interface{}, timeout int, args ...interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(actualTimeout)*time.Second)
 	defer cancel()
 
-	call := a.object.CallWithContext(ctx, "org.freedesktop.PolicyKit1.Authority."+action, 0, args...)
-	if call.Err != nil {
-		return call.Err
-	}
-
-	if result != nil {
-		if err := call.Store(result); err != nil {
-			return err
-		}
+	result, err := a.CheckAuthorizationContext(ctx, subject, actionID, details, flags, cancellationID)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("authorization check timed out after %d seconds", actualTimeout)
 	}
 
-	return nil
+	return result, err
 }
 
+// Deprecated: use CancelCheckAuthorizationContext instead.
+// CancelCheckAuthorization will be removed in a future release.
 func (a *Authority) CancelCheckAuthorization(cancellationID string) error {
-	if err := a.call("CancelCheckAuthorization", nil, cancellationID); err != nil {
-		return err
-	}
-	return nil
+	return a.CancelCheckAuthorizationContext(context.Background(), cancellationID)
 }
 
 func (a *Authority) call(action string, result interface{}, args ...interface{}) error {
@@ -0,0 +1,84 @@
+package polkit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// cancelCleanupTimeout bounds the best-effort CancelCheckAuthorization sent
+// after a CheckAuthorizationContext call is abandoned, so a wedged bus or
+// peer can't also hang the cleanup indefinitely.
+const cancelCleanupTimeout = 5 * time.Second
+
+// CheckAuthorizationContext is the context-first replacement for
+// CheckAuthorizationFor's trailing timeout ...int. If ctx is cancelled or its
+// deadline expires while the check is in flight, it returns ctx.Err()
+// unwrapped and best-effort issues a CancelCheckAuthorization for
+// cancellationID so the pending check on PolicyKit's side doesn't linger.
+func (a *Authority) CheckAuthorizationContext(
+	ctx context.Context,
+	subject PKSubject,
+	actionID string,
+	details map[string]string,
+	flags uint32,
+	cancellationID string) (*PKAuthorizationResult, error) {
+	result := PKAuthorizationResult{}
+	call := a.object.CallWithContext(ctx, "org.freedesktop.PolicyKit1.Authority.CheckAuthorization", 0, subject, actionID, details, flags, cancellationID)
+	if call.Err != nil {
+		if errors.Is(call.Err, context.DeadlineExceeded) || errors.Is(call.Err, context.Canceled) {
+			if cancellationID != "" {
+				go func() {
+					ctx, cancel := context.WithTimeout(context.Background(), cancelCleanupTimeout)
+					defer cancel()
+					_ = a.CancelCheckAuthorizationContext(ctx, cancellationID)
+				}()
+			}
+			return nil, call.Err
+		}
+		return nil, mapDBusError(call.Err)
+	}
+
+	if err := call.Store(&result); err != nil {
+		return nil, err
+	}
+
+	if err := mapAuthorizationResult(&result, flags); err != nil {
+		return &result, err
+	}
+
+	return &result, nil
+}
+
+// EnumerateActionsContext is the context-first replacement for
+// EnumerateActions.
+func (a *Authority) EnumerateActionsContext(ctx context.Context, locale string) ([]PKActionDescription, error) {
+	var result []PKActionDescription
+	call := a.object.CallWithContext(ctx, "org.freedesktop.PolicyKit1.Authority.EnumerateActions", 0, locale)
+	if call.Err != nil {
+		if errors.Is(call.Err, context.DeadlineExceeded) || errors.Is(call.Err, context.Canceled) {
+			return nil, call.Err
+		}
+		return nil, mapDBusError(call.Err)
+	}
+
+	if err := call.Store(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CancelCheckAuthorizationContext is the context-first replacement for
+// CancelCheckAuthorization.
+func (a *Authority) CancelCheckAuthorizationContext(ctx context.Context, cancellationID string) error {
+	call := a.object.CallWithContext(ctx, "org.freedesktop.PolicyKit1.Authority.CancelCheckAuthorization", 0, cancellationID)
+	if call.Err != nil {
+		if errors.Is(call.Err, context.DeadlineExceeded) || errors.Is(call.Err, context.Canceled) {
+			return call.Err
+		}
+		return mapDBusError(call.Err)
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package polkit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// fakeBusObject is a minimal dbus.BusObject stand-in that lets tests drive
+// CallWithContext's result without a real system bus.
+type fakeBusObject struct {
+	callWithContext func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call
+}
+
+func (f *fakeBusObject) Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	panic("not implemented")
+}
+
+func (f *fakeBusObject) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	return f.callWithContext(ctx, method, flags, args...)
+}
+
+func (f *fakeBusObject) Go(method string, flags dbus.Flags, ch chan *dbus.Call, args ...interface{}) *dbus.Call {
+	panic("not implemented")
+}
+
+func (f *fakeBusObject) GoWithContext(ctx context.Context, method string, flags dbus.Flags, ch chan *dbus.Call, args ...interface{}) *dbus.Call {
+	panic("not implemented")
+}
+
+func (f *fakeBusObject) AddMatchSignal(iface, member string, options ...dbus.MatchOption) *dbus.Call {
+	panic("not implemented")
+}
+
+func (f *fakeBusObject) RemoveMatchSignal(iface, member string, options ...dbus.MatchOption) *dbus.Call {
+	panic("not implemented")
+}
+
+func (f *fakeBusObject) GetProperty(p string) (dbus.Variant, error) { panic("not implemented") }
+
+func (f *fakeBusObject) StoreProperty(p string, value interface{}) error { panic("not implemented") }
+
+func (f *fakeBusObject) SetProperty(p string, v interface{}) error { panic("not implemented") }
+
+func (f *fakeBusObject) Destination() string { return "org.freedesktop.PolicyKit1" }
+
+func (f *fakeBusObject) Path() dbus.ObjectPath { return "/org/freedesktop/PolicyKit1/Authority" }
+
+func TestCheckAuthorizationContext(t *testing.T) {
+	t.Run("success maps the authorization result", func(t *testing.T) {
+		a := &Authority{object: &fakeBusObject{
+			callWithContext: func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+				return &dbus.Call{Body: []interface{}{PKAuthorizationResult{IsAuthorized: true}}}
+			},
+		}}
+
+		result, err := a.CheckAuthorizationContext(context.Background(), PKSubject{}, "org.example.foo", nil, CheckAuthorizationNone, "")
+		if err != nil {
+			t.Fatalf("CheckAuthorizationContext: unexpected error %v", err)
+		}
+		if !result.IsAuthorized {
+			t.Errorf("result.IsAuthorized = false, want true")
+		}
+	})
+
+	t.Run("dbus error is mapped through mapDBusError", func(t *testing.T) {
+		a := &Authority{object: &fakeBusObject{
+			callWithContext: func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+				return &dbus.Call{Err: dbus.Error{Name: "org.freedesktop.PolicyKit1.Error.Cancelled"}}
+			},
+		}}
+
+		_, err := a.CheckAuthorizationContext(context.Background(), PKSubject{}, "org.example.foo", nil, CheckAuthorizationNone, "cookie")
+		if !errors.Is(err, ErrCancelled) {
+			t.Errorf("CheckAuthorizationContext error = %v, want ErrCancelled", err)
+		}
+	})
+
+	t.Run("context cancellation returns ctx.Err unwrapped and fires a bounded cleanup cancel", func(t *testing.T) {
+		var (
+			mu          sync.Mutex
+			cleanupSeen bool
+			cleanupCtx  context.Context
+		)
+
+		a := &Authority{object: &fakeBusObject{
+			callWithContext: func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+				if method == "org.freedesktop.PolicyKit1.Authority.CheckAuthorization" {
+					return &dbus.Call{Err: context.Canceled}
+				}
+				if method == "org.freedesktop.PolicyKit1.Authority.CancelCheckAuthorization" {
+					mu.Lock()
+					cleanupSeen = true
+					cleanupCtx = ctx
+					mu.Unlock()
+					return &dbus.Call{}
+				}
+				t.Fatalf("unexpected method %q", method)
+				return nil
+			},
+		}}
+
+		_, err := a.CheckAuthorizationContext(context.Background(), PKSubject{}, "org.example.foo", nil, CheckAuthorizationNone, "cookie-123")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("CheckAuthorizationContext error = %v, want context.Canceled", err)
+		}
+
+		// The cleanup call is fired from a detached goroutine; give it a
+		// moment to run rather than asserting on it synchronously.
+		deadline := time.Now().Add(time.Second)
+		for {
+			mu.Lock()
+			seen := cleanupSeen
+			mu.Unlock()
+			if seen || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !cleanupSeen {
+			t.Fatal("CancelCheckAuthorization cleanup was never invoked")
+		}
+		if cleanupCtx == nil {
+			t.Fatal("cleanup context is nil")
+		}
+		deadlineAt, ok := cleanupCtx.Deadline()
+		if !ok {
+			t.Fatal("cleanup context has no deadline; want it bounded by cancelCleanupTimeout")
+		}
+		if remaining := time.Until(deadlineAt); remaining <= 0 || remaining > cancelCleanupTimeout {
+			t.Errorf("cleanup context deadline %v from now, want (0, %v]", remaining, cancelCleanupTimeout)
+		}
+	})
+
+	t.Run("no cleanup call when cancellationID is empty", func(t *testing.T) {
+		a := &Authority{object: &fakeBusObject{
+			callWithContext: func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+				if method == "org.freedesktop.PolicyKit1.Authority.CancelCheckAuthorization" {
+					t.Fatal("CancelCheckAuthorization should not be called without a cancellationID")
+				}
+				return &dbus.Call{Err: context.DeadlineExceeded}
+			},
+		}}
+
+		_, err := a.CheckAuthorizationContext(context.Background(), PKSubject{}, "org.example.foo", nil, CheckAuthorizationNone, "")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("CheckAuthorizationContext error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+}
+
+func TestEnumerateActionsContext(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		want := []PKActionDescription{{ActionID: "org.example.foo"}}
+		a := &Authority{object: &fakeBusObject{
+			callWithContext: func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+				return &dbus.Call{Body: []interface{}{want}}
+			},
+		}}
+
+		got, err := a.EnumerateActionsContext(context.Background(), "en_US")
+		if err != nil {
+			t.Fatalf("EnumerateActionsContext: unexpected error %v", err)
+		}
+		if len(got) != 1 || got[0].ActionID != want[0].ActionID {
+			t.Errorf("EnumerateActionsContext = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("dbus error is mapped", func(t *testing.T) {
+		a := &Authority{object: &fakeBusObject{
+			callWithContext: func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+				return &dbus.Call{Err: dbus.Error{Name: "org.freedesktop.PolicyKit1.Error.Failed", Body: []interface{}{"Action org.example.foo is not registered"}}}
+			},
+		}}
+
+		_, err := a.EnumerateActionsContext(context.Background(), "en_US")
+		if !errors.Is(err, ErrUnknownAction) {
+			t.Errorf("EnumerateActionsContext error = %v, want ErrUnknownAction", err)
+		}
+	})
+}
+
+func TestCancelCheckAuthorizationContext(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		a := &Authority{object: &fakeBusObject{
+			callWithContext: func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+				return &dbus.Call{}
+			},
+		}}
+
+		if err := a.CancelCheckAuthorizationContext(context.Background(), "cookie"); err != nil {
+			t.Errorf("CancelCheckAuthorizationContext: unexpected error %v", err)
+		}
+	})
+
+	t.Run("context deadline exceeded is returned unwrapped", func(t *testing.T) {
+		a := &Authority{object: &fakeBusObject{
+			callWithContext: func(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+				return &dbus.Call{Err: context.DeadlineExceeded}
+			},
+		}}
+
+		err := a.CancelCheckAuthorizationContext(context.Background(), "cookie")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("CancelCheckAuthorizationContext error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+}
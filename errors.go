@@ -0,0 +1,73 @@
+package polkit
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+var (
+	// ErrDismissed is returned when the user dismissed the authentication
+	// dialog instead of completing it.
+	ErrDismissed = errors.New("polkit: authentication dialog dismissed by user")
+
+	// ErrCancelled is returned when an authorization check was cancelled,
+	// either by the caller via CancelCheckAuthorization or by PolicyKit
+	// itself.
+	ErrCancelled = errors.New("polkit: authorization check cancelled")
+
+	// ErrInteractionRequired is returned when the action requires
+	// authentication but the caller did not set
+	// CheckAuthorizationAllowUserInteraction.
+	ErrInteractionRequired = errors.New("polkit: interaction required but not allowed")
+
+	// ErrUnknownAction is returned when the requested action ID is not
+	// registered with PolicyKit.
+	ErrUnknownAction = errors.New("polkit: unknown action")
+)
+
+// dismissedDetail is the key PolicyKit sets in PKAuthorizationResult.Details
+// when the user closed the authentication dialog without authenticating.
+const dismissedDetail = "polkit.dismissed"
+
+// mapAuthorizationResult turns a successful CheckAuthorization reply into
+// ErrDismissed when the polkit.dismissed detail is set, or into
+// ErrInteractionRequired when the result is an unauthorized challenge and
+// CheckAuthorizationAllowUserInteraction was not set, so callers can use
+// errors.Is instead of inspecting PKAuthorizationResult by hand. Any other
+// result, including a plain unauthorized one, yields nil.
+func mapAuthorizationResult(result *PKAuthorizationResult, flags uint32) error {
+	if result.Details[dismissedDetail] == "true" {
+		return ErrDismissed
+	}
+
+	if result.IsChallenge && !result.IsAuthorized && flags&CheckAuthorizationAllowUserInteraction == 0 {
+		return ErrInteractionRequired
+	}
+
+	return nil
+}
+
+// mapDBusError translates the well-known org.freedesktop.PolicyKit1.Error.*
+// D-Bus error names into sentinel errors so callers don't have to string
+// match on dbus.Error.Name themselves.
+func mapDBusError(err error) error {
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) {
+		return err
+	}
+
+	switch dbusErr.Name {
+	case "org.freedesktop.PolicyKit1.Error.Cancelled":
+		return ErrCancelled
+	case "org.freedesktop.PolicyKit1.Error.Failed":
+		if len(dbusErr.Body) > 0 {
+			if msg, ok := dbusErr.Body[0].(string); ok && strings.Contains(msg, "is not registered") {
+				return ErrUnknownAction
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,118 @@
+package polkit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestMapDBusError(t *testing.T) {
+	// dbus.Error embeds a []interface{} body, so it isn't comparable with ==
+	// or errors.Is; assert on the sentinel (or lack thereof) plus the
+	// original message surviving instead.
+	tests := []struct {
+		name        string
+		err         dbus.Error
+		wantErr     error // the sentinel mapDBusError should produce, or nil for "unchanged"
+		wantUnwraps bool  // when true, the original dbus.Error message should still be reachable
+	}{
+		{
+			name:    "cancelled",
+			err:     dbus.Error{Name: "org.freedesktop.PolicyKit1.Error.Cancelled"},
+			wantErr: ErrCancelled,
+		},
+		{
+			name:    "failed with unknown action message",
+			err:     dbus.Error{Name: "org.freedesktop.PolicyKit1.Error.Failed", Body: []interface{}{"The action org.example.foo is not registered"}},
+			wantErr: ErrUnknownAction,
+		},
+		{
+			name:        "failed with unrelated message",
+			err:         dbus.Error{Name: "org.freedesktop.PolicyKit1.Error.Failed", Body: []interface{}{"something else went wrong"}},
+			wantUnwraps: true,
+		},
+		{
+			name:        "failed with no body",
+			err:         dbus.Error{Name: "org.freedesktop.PolicyKit1.Error.Failed"},
+			wantUnwraps: true,
+		},
+		{
+			name:        "unrecognized dbus error name",
+			err:         dbus.Error{Name: "org.freedesktop.PolicyKit1.Error.NotSupported"},
+			wantUnwraps: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapDBusError(tt.err)
+			if tt.wantErr != nil {
+				if !errors.Is(got, tt.wantErr) {
+					t.Errorf("mapDBusError(%+v) = %v, want errors.Is(_, %v)", tt.err, got, tt.wantErr)
+				}
+				return
+			}
+
+			if tt.wantUnwraps {
+				gotDBusErr, ok := got.(dbus.Error)
+				if !ok {
+					t.Fatalf("mapDBusError(%+v) = %v (%T), want the original dbus.Error unchanged", tt.err, got, got)
+				}
+				if gotDBusErr.Name != tt.err.Name {
+					t.Errorf("mapDBusError(%+v).Name = %q, want %q", tt.err, gotDBusErr.Name, tt.err.Name)
+				}
+			}
+		})
+	}
+
+	t.Run("not a dbus error", func(t *testing.T) {
+		err := errors.New("boom")
+		if got := mapDBusError(err); got != err {
+			t.Errorf("mapDBusError(%v) = %v, want unchanged", err, got)
+		}
+	})
+}
+
+func TestMapAuthorizationResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		result PKAuthorizationResult
+		flags  uint32
+		want   error
+	}{
+		{
+			name:   "authorized",
+			result: PKAuthorizationResult{IsAuthorized: true},
+		},
+		{
+			name:   "dismissed",
+			result: PKAuthorizationResult{Details: map[string]string{dismissedDetail: "true"}},
+			want:   ErrDismissed,
+		},
+		{
+			name:   "challenge without interaction allowed",
+			result: PKAuthorizationResult{IsChallenge: true, IsAuthorized: false},
+			flags:  CheckAuthorizationNone,
+			want:   ErrInteractionRequired,
+		},
+		{
+			name:   "challenge with interaction allowed",
+			result: PKAuthorizationResult{IsChallenge: true, IsAuthorized: false},
+			flags:  CheckAuthorizationAllowUserInteraction,
+		},
+		{
+			name:   "challenge but already authorized",
+			result: PKAuthorizationResult{IsChallenge: true, IsAuthorized: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapAuthorizationResult(&tt.result, tt.flags)
+			if !errors.Is(got, tt.want) {
+				t.Errorf("mapAuthorizationResult(%+v, %d) = %v, want %v", tt.result, tt.flags, got, tt.want)
+			}
+		})
+	}
+}
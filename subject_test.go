@@ -0,0 +1,60 @@
+package polkit
+
+import "testing"
+
+func TestParseStatStartTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		stat    string
+		want    uint64
+		wantErr bool
+	}{
+		{
+			name: "typical",
+			stat: "42 (bash) S 1 42 42 0 -1 4194304 " +
+				"0 0 0 0 0 0 0 0 20 0 1 0 1234567" +
+				" 0 0 18446744073709551615 0 0 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0 0",
+			want: 1234567,
+		},
+		{
+			name: "comm contains spaces and a closing paren",
+			stat: "42 (my weird) proc) S 1 42 42 0 -1 4194304 " +
+				"0 0 0 0 0 0 0 0 20 0 1 0 987" +
+				" 0 0 18446744073709551615 0 0 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0 0",
+			want: 987,
+		},
+		{
+			name:    "no closing paren",
+			stat:    "42 bash S 1 42",
+			wantErr: true,
+		},
+		{
+			name:    "truncated before field 22",
+			stat:    "42 (bash) S 1 42",
+			wantErr: true,
+		},
+		{
+			name:    "start time field is not numeric",
+			stat:    "42 (bash) S 1 42 42 0 -1 4194304 0 0 0 0 0 0 0 0 20 0 1 0 nope",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStatStartTime([]byte(tt.stat))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseStatStartTime(%q) = %d, nil; want error", tt.stat, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseStatStartTime(%q) returned unexpected error: %v", tt.stat, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseStatStartTime(%q) = %d, want %d", tt.stat, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package polkit
+
+import "time"
+
+// PKTemporaryAuthorization describes one of the "auth_*_keep" grants
+// recorded by PolicyKit after a user authenticates with retained
+// authorization (see PKImplicitAuthorization's *Retained variants).
+type PKTemporaryAuthorization struct {
+	ID           string
+	ActionID     string
+	Subject      PKSubject
+	TimeObtained time.Time
+	TimeExpires  time.Time
+}
+
+// pkTemporaryAuthorizationRaw mirrors the wire representation of
+// PKTemporaryAuthorization: PolicyKit reports the obtained/expiry times as
+// seconds-since-epoch integers rather than a D-Bus native time type.
+type pkTemporaryAuthorizationRaw struct {
+	ID           string    `dbus:"id"`
+	ActionID     string    `dbus:"action_id"`
+	Subject      PKSubject `dbus:"subject"`
+	TimeObtained uint64    `dbus:"time_obtained"`
+	TimeExpires  uint64    `dbus:"time_expires"`
+}
+
+// EnumerateTemporaryAuthorizations lists the temporary authorizations
+// currently held by subject.
+func (a *Authority) EnumerateTemporaryAuthorizations(subject PKSubject) ([]PKTemporaryAuthorization, error) {
+	var raw []pkTemporaryAuthorizationRaw
+	if err := a.call("EnumerateTemporaryAuthorizations", &raw, subject); err != nil {
+		return nil, err
+	}
+
+	result := make([]PKTemporaryAuthorization, len(raw))
+	for i, r := range raw {
+		result[i] = toPKTemporaryAuthorization(r)
+	}
+
+	return result, nil
+}
+
+// toPKTemporaryAuthorization converts the wire representation of a temporary
+// authorization, with its seconds-since-epoch timestamps, into the public
+// PKTemporaryAuthorization type.
+func toPKTemporaryAuthorization(r pkTemporaryAuthorizationRaw) PKTemporaryAuthorization {
+	return PKTemporaryAuthorization{
+		ID:           r.ID,
+		ActionID:     r.ActionID,
+		Subject:      r.Subject,
+		TimeObtained: time.Unix(int64(r.TimeObtained), 0),
+		TimeExpires:  time.Unix(int64(r.TimeExpires), 0),
+	}
+}
+
+// RevokeTemporaryAuthorizations revokes every temporary authorization held
+// by subject.
+func (a *Authority) RevokeTemporaryAuthorizations(subject PKSubject) error {
+	return a.call("RevokeTemporaryAuthorizations", nil, subject)
+}
+
+// RevokeTemporaryAuthorizationById revokes a single temporary authorization
+// by the ID reported in PKTemporaryAuthorization.ID.
+func (a *Authority) RevokeTemporaryAuthorizationById(id string) error {
+	return a.call("RevokeTemporaryAuthorizationById", nil, id)
+}
@@ -0,0 +1,79 @@
+package polkit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestToPKTemporaryAuthorization(t *testing.T) {
+	subject := NewUnixSessionSubject("session-1")
+
+	tests := []struct {
+		name string
+		raw  pkTemporaryAuthorizationRaw
+		want PKTemporaryAuthorization
+	}{
+		{
+			name: "typical grant",
+			raw: pkTemporaryAuthorizationRaw{
+				ID:           "auth-1",
+				ActionID:     "org.example.foo",
+				Subject:      subject,
+				TimeObtained: 1700000000,
+				TimeExpires:  1700000300,
+			},
+			want: PKTemporaryAuthorization{
+				ID:           "auth-1",
+				ActionID:     "org.example.foo",
+				Subject:      subject,
+				TimeObtained: time.Unix(1700000000, 0),
+				TimeExpires:  time.Unix(1700000300, 0),
+			},
+		},
+		{
+			name: "zero times decode to the epoch, not a negative time",
+			raw: pkTemporaryAuthorizationRaw{
+				ID:       "auth-2",
+				ActionID: "org.example.bar",
+			},
+			want: PKTemporaryAuthorization{
+				ID:           "auth-2",
+				ActionID:     "org.example.bar",
+				TimeObtained: time.Unix(0, 0),
+				TimeExpires:  time.Unix(0, 0),
+			},
+		},
+		{
+			name: "time far enough out to exercise the top bit of a uint32",
+			raw: pkTemporaryAuthorizationRaw{
+				ID:           "auth-3",
+				TimeObtained: 4102444800, // 2100-01-01 UTC
+				TimeExpires:  4102444800,
+			},
+			want: PKTemporaryAuthorization{
+				ID:           "auth-3",
+				TimeObtained: time.Unix(4102444800, 0),
+				TimeExpires:  time.Unix(4102444800, 0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toPKTemporaryAuthorization(tt.raw)
+			// PKSubject carries a map field, so compare it with
+			// reflect.DeepEqual rather than == (which Go disallows for
+			// structs with non-comparable fields).
+			if got.ID != tt.want.ID || got.ActionID != tt.want.ActionID || !reflect.DeepEqual(got.Subject, tt.want.Subject) {
+				t.Errorf("toPKTemporaryAuthorization(%+v) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+			if !got.TimeObtained.Equal(tt.want.TimeObtained) {
+				t.Errorf("toPKTemporaryAuthorization(%+v).TimeObtained = %v, want %v", tt.raw, got.TimeObtained, tt.want.TimeObtained)
+			}
+			if !got.TimeExpires.Equal(tt.want.TimeExpires) {
+				t.Errorf("toPKTemporaryAuthorization(%+v).TimeExpires = %v, want %v", tt.raw, got.TimeExpires, tt.want.TimeExpires)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package polkit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const authorityInterface = "org.freedesktop.PolicyKit1.Authority"
+
+// ErrWatchDisconnected is delivered on Watch's error channel when the
+// underlying connection to the system bus is lost while watching for
+// Changed signals. It is not reported when ctx is cancelled.
+var ErrWatchDisconnected = errors.New("polkit: lost connection to the system bus while watching for changes")
+
+// Watch subscribes to the Authority's Changed signal, which PolicyKit emits
+// whenever .policy or .rules files are reloaded, and delivers a notification
+// on the returned channel when it fires. The channel holds at most one
+// pending notification; signals that arrive while one is still unread are
+// folded into it. This lets long-lived daemons
+// invalidate cached EnumerateActions results or re-evaluate authorization
+// state instead of polling.
+//
+// The notification channel is closed and the subscription torn down when
+// ctx is cancelled. If the connection to the bus is lost instead, Watch does
+// not attempt to reconnect: it delivers ErrWatchDisconnected on the returned
+// error channel and closes both channels, so callers can tell a dead watch
+// apart from a quiet one and re-establish it (e.g. via a new Authority) if
+// they want to keep watching.
+func (a *Authority) Watch(ctx context.Context) (<-chan struct{}, <-chan error, error) {
+	matchOptions := []dbus.MatchOption{
+		dbus.WithMatchInterface(authorityInterface),
+		dbus.WithMatchMember("Changed"),
+	}
+
+	signals := make(chan *dbus.Signal, 16)
+	a.conn.Signal(signals)
+
+	if err := a.conn.AddMatchSignalContext(ctx, matchOptions...); err != nil {
+		a.conn.RemoveSignal(signals)
+		return nil, nil, err
+	}
+
+	changed := make(chan struct{}, 1)
+	watchErr := make(chan error, 1)
+	go func() {
+		defer a.conn.RemoveSignal(signals)
+		defer a.conn.RemoveMatchSignal(matchOptions...)
+		watchLoop(ctx, signals, changed, watchErr)
+	}()
+
+	return changed, watchErr, nil
+}
+
+// isAuthorityChangedSignal reports whether sig is the Authority's Changed
+// signal, as opposed to some other signal delivered on the same connection.
+func isAuthorityChangedSignal(sig *dbus.Signal) bool {
+	return sig.Name == authorityInterface+".Changed"
+}
+
+// watchLoop drains signals until ctx is cancelled or signals is closed
+// (indicating the bus connection was lost), forwarding each
+// Authority.Changed signal to changed. It closes changed and watchErr itself
+// before returning, delivering ErrWatchDisconnected on watchErr only when it
+// exits because signals was closed out from under it. It is split out from
+// Watch so the filtering/select logic can be driven with a plain channel in
+// tests instead of a real *dbus.Conn.
+func watchLoop(ctx context.Context, signals <-chan *dbus.Signal, changed chan<- struct{}, watchErr chan<- error) {
+	defer close(changed)
+	defer close(watchErr)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig, ok := <-signals:
+			if !ok {
+				watchErr <- ErrWatchDisconnected
+				return
+			}
+			if !isAuthorityChangedSignal(sig) {
+				continue
+			}
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// OnChanged is a callback-based wrapper around Watch for callers who'd
+// rather not manage channels themselves. onChanged is invoked, possibly
+// concurrently with the caller, once per notification Watch delivers (Changed
+// signals that arrive while onChanged is still running may be folded into a
+// single call) until ctx is cancelled or the watch dies. If the watch dies because the connection to
+// the bus was lost, onError (if non-nil) is called once with
+// ErrWatchDisconnected; it is not called on ordinary ctx cancellation.
+func (a *Authority) OnChanged(ctx context.Context, onChanged func(), onError func(error)) error {
+	changed, watchErr, err := a.Watch(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for range changed {
+			onChanged()
+		}
+		if err, ok := <-watchErr; ok && onError != nil {
+			onError(err)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package polkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestIsAuthorityChangedSignal(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  *dbus.Signal
+		want bool
+	}{
+		{
+			name: "changed signal",
+			sig:  &dbus.Signal{Name: "org.freedesktop.PolicyKit1.Authority.Changed"},
+			want: true,
+		},
+		{
+			name: "unrelated signal on the same bus",
+			sig:  &dbus.Signal{Name: "org.freedesktop.DBus.NameOwnerChanged"},
+			want: false,
+		},
+		{
+			name: "similarly-named but different interface",
+			sig:  &dbus.Signal{Name: "com.example.Other.Changed"},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAuthorityChangedSignal(tt.sig); got != tt.want {
+				t.Errorf("isAuthorityChangedSignal(%+v) = %v, want %v", tt.sig, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchLoopForwardsChangedSignals(t *testing.T) {
+	signals := make(chan *dbus.Signal, 4)
+	changed := make(chan struct{}, 1)
+	watchErr := make(chan error, 1)
+
+	signals <- &dbus.Signal{Name: "org.freedesktop.DBus.NameOwnerChanged"}
+	signals <- &dbus.Signal{Name: "org.freedesktop.PolicyKit1.Authority.Changed"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watchLoop(ctx, signals, changed, watchErr)
+		close(done)
+	}()
+
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("watchLoop did not forward the Changed signal")
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watchLoop did not return after ctx was cancelled")
+	}
+
+	if _, ok := <-changed; ok {
+		t.Error("changed channel was not closed on ctx cancellation")
+	}
+	if err, ok := <-watchErr; ok {
+		t.Errorf("watchErr delivered %v on ordinary ctx cancellation, want channel closed with nothing sent", err)
+	}
+}
+
+func TestWatchLoopReportsDisconnectOnClosedSignals(t *testing.T) {
+	signals := make(chan *dbus.Signal)
+	changed := make(chan struct{}, 1)
+	watchErr := make(chan error, 1)
+
+	done := make(chan struct{})
+	go func() {
+		watchLoop(context.Background(), signals, changed, watchErr)
+		close(done)
+	}()
+
+	close(signals)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watchLoop did not return after signals was closed")
+	}
+
+	err, ok := <-watchErr
+	if !ok {
+		t.Fatal("watchErr was closed without delivering an error")
+	}
+	if !errors.Is(err, ErrWatchDisconnected) {
+		t.Errorf("watchErr = %v, want ErrWatchDisconnected", err)
+	}
+
+	if _, ok := <-changed; ok {
+		t.Error("changed channel should be closed once watchLoop returns")
+	}
+}